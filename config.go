@@ -0,0 +1,214 @@
+package rediscache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/betas-in/logger"
+	"github.com/go-redis/redis/v8"
+)
+
+// Mode selects the redis topology NewCache connects to.
+type Mode string
+
+const (
+	// ModeStandalone talks to a single redis node. This is the default.
+	ModeStandalone Mode = "standalone"
+	// ModeSentinel talks to a redis master behind sentinels, with automatic failover.
+	ModeSentinel Mode = "sentinel"
+	// ModeCluster talks to a redis cluster.
+	ModeCluster Mode = "cluster"
+)
+
+type cache struct {
+	client redis.UniversalClient
+	log    *logger.Logger
+	config *Config
+}
+
+// Config configures NewCache. Either set URI, or set Mode plus the fields
+// relevant to that mode (Host/Port for standalone, SentinelAddrs/MasterName
+// for sentinel, ClusterAddrs for cluster).
+type Config struct {
+	// URI, when set, is parsed and takes precedence over every other
+	// connection field. Supported schemes are redis://, rediss:// (TLS) and
+	// redis+sentinel://.
+	URI string
+
+	// Mode selects standalone, sentinel or cluster. Defaults to standalone.
+	Mode Mode
+
+	Host string
+	Port int
+	// Username enables Redis 6+ ACL AUTH. Leave empty for the legacy
+	// password-only AUTH.
+	Username string
+	Password string
+	DB       int
+
+	// SentinelAddrs, MasterName and SentinelPassword are used when Mode is ModeSentinel.
+	SentinelAddrs    []string
+	MasterName       string
+	SentinelPassword string
+
+	// ClusterAddrs is used when Mode is ModeCluster.
+	ClusterAddrs []string
+
+	// TLSEnabled is set automatically when URI uses the rediss:// scheme, or
+	// can be set directly to dial with TLS without a URI.
+	TLSEnabled bool
+	// CAFile, CertFile and KeyFile are optional PEM file paths used to build
+	// the *tls.Config. CAFile verifies the server; CertFile/KeyFile are used
+	// together for mutual TLS.
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool
+	ServerName         string
+
+	MaxRetries int
+	PoolSize   int
+
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	MinIdleConns int
+	IdleTimeout  time.Duration
+	MaxConnAge   time.Duration
+}
+
+// NewCache ...
+func NewCache(conf *Config, log *logger.Logger) (Cache, error) {
+	c := cache{
+		log:    log,
+		config: conf,
+	}
+
+	if c.config.URI != "" {
+		err := c.config.parseURI(c.config.URI)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.defaults()
+
+	client, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	_, err = client.Ping(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("rediscache: could not ping redis: %w", err)
+	}
+	c.client = client
+
+	return &c, nil
+}
+
+func (c *cache) newClient() (redis.UniversalClient, error) {
+	tlsConfig, err := c.config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.config.Mode {
+	case ModeSentinel:
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       c.config.MasterName,
+			SentinelAddrs:    c.config.SentinelAddrs,
+			SentinelPassword: c.config.SentinelPassword,
+			Username:         c.config.Username,
+			Password:         c.config.Password,
+			DB:               c.config.DB,
+			TLSConfig:        tlsConfig,
+			MaxRetries:       c.config.MaxRetries,
+			PoolSize:         c.config.PoolSize,
+			MinIdleConns:     c.config.MinIdleConns,
+			DialTimeout:      c.config.DialTimeout,
+			ReadTimeout:      c.config.ReadTimeout,
+			WriteTimeout:     c.config.WriteTimeout,
+			IdleTimeout:      c.config.IdleTimeout,
+			MaxConnAge:       c.config.MaxConnAge,
+			OnConnect: func(ctx context.Context, conn *redis.Conn) error {
+				c.log.Info("redis").Msgf("connected to sentinel master %s", c.config.MasterName)
+				return nil
+			},
+		}), nil
+	case ModeCluster:
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        c.config.ClusterAddrs,
+			Username:     c.config.Username,
+			Password:     c.config.Password,
+			TLSConfig:    tlsConfig,
+			MaxRetries:   c.config.MaxRetries,
+			PoolSize:     c.config.PoolSize,
+			MinIdleConns: c.config.MinIdleConns,
+			DialTimeout:  c.config.DialTimeout,
+			ReadTimeout:  c.config.ReadTimeout,
+			WriteTimeout: c.config.WriteTimeout,
+			IdleTimeout:  c.config.IdleTimeout,
+			MaxConnAge:   c.config.MaxConnAge,
+			OnConnect: func(ctx context.Context, conn *redis.Conn) error {
+				c.log.Info("redis").Msgf("connected to cluster %v", c.config.ClusterAddrs)
+				return nil
+			},
+		}), nil
+	case ModeStandalone, "":
+		return redis.NewClient(&redis.Options{
+			Addr:         fmt.Sprintf("%s:%d", c.config.Host, c.config.Port),
+			Username:     c.config.Username,
+			Password:     c.config.Password,
+			DB:           c.config.DB,
+			TLSConfig:    tlsConfig,
+			MaxRetries:   c.config.MaxRetries,
+			PoolSize:     c.config.PoolSize,
+			MinIdleConns: c.config.MinIdleConns,
+			DialTimeout:  c.config.DialTimeout,
+			ReadTimeout:  c.config.ReadTimeout,
+			WriteTimeout: c.config.WriteTimeout,
+			IdleTimeout:  c.config.IdleTimeout,
+			MaxConnAge:   c.config.MaxConnAge,
+			OnConnect: func(ctx context.Context, conn *redis.Conn) error {
+				c.log.Info("redis").Msgf("connected to %s:%d", c.config.Host, c.config.Port)
+				return nil
+			},
+		}), nil
+	default:
+		return nil, fmt.Errorf("rediscache: unknown mode %q", c.config.Mode)
+	}
+}
+
+func (c *cache) defaults() {
+	if c.config.Mode == "" {
+		c.config.Mode = ModeStandalone
+	}
+	if c.config.Host == "" {
+		c.config.Host = "127.0.0.1"
+	}
+	if c.config.Port == 0 {
+		c.config.Port = 6379
+	}
+	if c.config.DB == 0 {
+		c.config.DB = 0
+	}
+	if c.config.MaxRetries == 0 {
+		c.config.MaxRetries = 3
+	}
+	if c.config.PoolSize == 0 {
+		c.config.PoolSize = 10
+	}
+}
+
+// GetClient the redis object
+func (c *cache) GetClient() redis.UniversalClient {
+	return c.client
+}
+
+// Close the redis object
+func (c *cache) Close() error {
+	return c.client.Close()
+}
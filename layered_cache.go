@@ -0,0 +1,128 @@
+package rediscache
+
+import (
+	"context"
+	"time"
+)
+
+// LayerOptions configures NewLayeredCache.
+type LayerOptions struct {
+	// LocalCacheSize bounds the number of hot keys held in process. Defaults
+	// to 10000.
+	LocalCacheSize int
+	// LocalCacheTTL bounds how long a local entry is trusted before it is
+	// re-fetched from redis, even without an invalidation. Zero means a local
+	// entry only expires when it is invalidated.
+	LocalCacheTTL time.Duration
+	// InvalidationChannel is the pub/sub channel every LayeredCache publishes
+	// a key to on Set/Del/Expire, so other processes evict their local copy.
+	// Defaults to "rediscache:invalidate".
+	InvalidationChannel string
+}
+
+func (opts *LayerOptions) defaults() {
+	if opts.LocalCacheSize == 0 {
+		opts.LocalCacheSize = 10000
+	}
+	if opts.InvalidationChannel == "" {
+		opts.InvalidationChannel = "rediscache:invalidate"
+	}
+}
+
+// layeredCache checks a bounded in-process LRU before falling back to the
+// wrapped Cache, and fans out invalidations to other processes over redis
+// pub/sub so their local copies stay coherent.
+type layeredCache struct {
+	Cache
+	local  *localLRU
+	opts   LayerOptions
+	cancel context.CancelFunc
+}
+
+// NewLayeredCache wraps inner with an in-process LRU so repeated reads of a
+// hot key avoid a round-trip to redis. Callers opt in by wrapping an existing
+// Cache; every other call site keeps using the Cache interface unchanged.
+func NewLayeredCache(inner Cache, opts LayerOptions) Cache {
+	opts.defaults()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lc := &layeredCache{
+		Cache:  inner,
+		local:  newLocalLRU(opts.LocalCacheSize, opts.LocalCacheTTL),
+		opts:   opts,
+		cancel: cancel,
+	}
+
+	go lc.watchInvalidations(ctx)
+
+	return lc
+}
+
+func (lc *layeredCache) watchInvalidations(ctx context.Context) {
+	sub := lc.Cache.Subscribe(ctx, lc.opts.InvalidationChannel)
+	defer sub.Close()
+
+	for msg := range sub.Channel() {
+		lc.local.del(msg.Payload)
+	}
+}
+
+func (lc *layeredCache) invalidate(ctx context.Context, key string) {
+	lc.local.del(key)
+	lc.Cache.Publish(ctx, lc.opts.InvalidationChannel, key)
+}
+
+// Get checks the local LRU before falling back to the wrapped Cache.
+func (lc *layeredCache) Get(ctx context.Context, key string) (string, error) {
+	if value, ok := lc.local.get(key); ok {
+		return value, nil
+	}
+
+	value, err := lc.Cache.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+	if value != "" {
+		lc.local.set(key, value)
+	}
+	return value, nil
+}
+
+// Set writes through to the wrapped Cache, then invalidates key everywhere so
+// the next Get re-populates from redis.
+func (lc *layeredCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) (string, error) {
+	response, err := lc.Cache.Set(ctx, key, value, expiration)
+	if err != nil {
+		return response, err
+	}
+	lc.invalidate(ctx, key)
+	return response, nil
+}
+
+// Del in redis, then invalidates every key everywhere.
+func (lc *layeredCache) Del(ctx context.Context, keys ...string) (int64, error) {
+	response, err := lc.Cache.Del(ctx, keys...)
+	if err != nil {
+		return response, err
+	}
+	for _, key := range keys {
+		lc.invalidate(ctx, key)
+	}
+	return response, nil
+}
+
+// Expire in redis, then invalidates key everywhere since its TTL changed.
+func (lc *layeredCache) Expire(ctx context.Context, key string, expiration time.Duration) (bool, error) {
+	response, err := lc.Cache.Expire(ctx, key, expiration)
+	if err != nil {
+		return response, err
+	}
+	lc.invalidate(ctx, key)
+	return response, nil
+}
+
+// Close stops the invalidation listener, then closes the wrapped Cache.
+func (lc *layeredCache) Close() error {
+	lc.cancel()
+	return lc.Cache.Close()
+}
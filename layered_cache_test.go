@@ -0,0 +1,145 @@
+package rediscache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/betas-in/logger"
+	"github.com/betas-in/utils"
+)
+
+func benchConfig() *Config {
+	return &Config{
+		Host:     "127.0.0.1",
+		Port:     9876,
+		Password: "596a96cc7bf9108cd896f33c44aedc8a",
+		DB:       0,
+	}
+}
+
+// countingCache wraps a Cache and counts calls to Get, so tests can assert
+// whether a LayeredCache actually served a Get from its local LRU instead of
+// falling through to the wrapped Cache.
+type countingCache struct {
+	Cache
+	gets int
+}
+
+func (c *countingCache) Get(ctx context.Context, key string) (string, error) {
+	c.gets++
+	return c.Cache.Get(ctx, key)
+}
+
+func TestLayeredCacheServesHotKeysFromLocalLRU(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	inner, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	counting := &countingCache{Cache: inner}
+	c := NewLayeredCache(counting, LayerOptions{LocalCacheSize: 100})
+	defer c.Close()
+
+	ctx := context.Background()
+	_, err = c.Set(ctx, "layered:hot", "value", time.Minute)
+	utils.Test().Nil(t, err)
+
+	value, err := c.Get(ctx, "layered:hot")
+	utils.Test().Nil(t, err)
+	utils.Test().Equals(t, "value", value)
+	getsAfterFirst := counting.gets
+
+	value, err = c.Get(ctx, "layered:hot")
+	utils.Test().Nil(t, err)
+	utils.Test().Equals(t, "value", value)
+	utils.Test().Equals(t, getsAfterFirst, counting.gets)
+}
+
+func TestLayeredCacheInvalidatesOnSet(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	inner, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	counting := &countingCache{Cache: inner}
+	c := NewLayeredCache(counting, LayerOptions{LocalCacheSize: 100})
+	defer c.Close()
+
+	ctx := context.Background()
+	_, err = c.Set(ctx, "layered:invalidate", "first", time.Minute)
+	utils.Test().Nil(t, err)
+	_, err = c.Get(ctx, "layered:invalidate")
+	utils.Test().Nil(t, err)
+	getsAfterFirst := counting.gets
+
+	_, err = c.Set(ctx, "layered:invalidate", "second", time.Minute)
+	utils.Test().Nil(t, err)
+	// Set drops the local entry before it returns, and also publishes the key,
+	// which this process's own listener receives too; give that message time
+	// to arrive before reading again.
+	time.Sleep(50 * time.Millisecond)
+
+	value, err := c.Get(ctx, "layered:invalidate")
+	utils.Test().Nil(t, err)
+	utils.Test().Equals(t, "second", value)
+	if counting.gets <= getsAfterFirst {
+		t.Fatalf("expected Set to invalidate the local entry, forcing a redis round-trip on the next Get")
+	}
+}
+
+// BenchmarkCacheGet_NoLayer hits redis on every Get.
+func BenchmarkCacheGet_NoLayer(b *testing.B) {
+	log := logger.NewLogger(0, true)
+	c, err := NewCache(benchConfig(), log)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	_, err = c.Set(ctx, "bench:hot", "value", time.Minute)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := c.Get(ctx, "bench:hot")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLayeredCacheGet_Hot serves repeat Gets of a hot key from the local
+// LRU, avoiding the redis round-trip BenchmarkCacheGet_NoLayer pays every time.
+func BenchmarkLayeredCacheGet_Hot(b *testing.B) {
+	log := logger.NewLogger(0, true)
+	inner, err := NewCache(benchConfig(), log)
+	if err != nil {
+		b.Fatal(err)
+	}
+	c := NewLayeredCache(inner, LayerOptions{LocalCacheSize: 100})
+	defer c.Close()
+
+	ctx := context.Background()
+	_, err = c.Set(ctx, "bench:hot", "value", time.Minute)
+	if err != nil {
+		b.Fatal(err)
+	}
+	_, err = c.Get(ctx, "bench:hot")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := c.Get(ctx, "bench:hot")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
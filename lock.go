@@ -0,0 +1,243 @@
+package rediscache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math"
+	mathrand "math/rand"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrNotObtained is returned by Obtain when the lock could not be acquired
+// from a quorum of instances within the configured retries/max wait.
+var ErrNotObtained = errors.New("rediscache: lock not obtained")
+
+// clockDriftFactor accounts for redis clocks drifting relative to each other
+// across a quorum of instances, per the Redlock algorithm.
+const clockDriftFactor = 0.01
+
+var releaseScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+var refreshScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// RetryStrategy selects how Obtain waits between acquisition attempts.
+type RetryStrategy int
+
+const (
+	// RetryNone makes Obtain fail immediately if the lock isn't free.
+	RetryNone RetryStrategy = iota
+	// RetryFixed waits RetryInterval (plus jitter) between attempts.
+	RetryFixed
+	// RetryExponentialBackoff doubles the wait (plus jitter) after every
+	// attempt, starting from RetryInterval.
+	RetryExponentialBackoff
+)
+
+// ObtainOptions configures how Obtain retries while the lock is held by
+// someone else.
+type ObtainOptions struct {
+	RetryStrategy RetryStrategy
+	// RetryInterval is the base delay between attempts for RetryFixed, and
+	// the starting delay for RetryExponentialBackoff. Defaults to 100ms.
+	RetryInterval time.Duration
+	// Jitter is a random duration up to this value added to every retry
+	// delay, to avoid retry storms. Defaults to RetryInterval / 2.
+	Jitter time.Duration
+	// MaxRetries bounds the number of retries after the first attempt. Zero means RetryNone
+	// semantics regardless of RetryStrategy.
+	MaxRetries int
+	// MaxWait bounds the total time Obtain spends retrying. Zero means no
+	// bound beyond MaxRetries.
+	MaxWait time.Duration
+}
+
+func (opts *ObtainOptions) defaults() {
+	if opts.RetryInterval == 0 {
+		opts.RetryInterval = 100 * time.Millisecond
+	}
+	if opts.Jitter == 0 {
+		opts.Jitter = opts.RetryInterval / 2
+	}
+}
+
+func (opts *ObtainOptions) delay(attempt int) time.Duration {
+	base := opts.RetryInterval
+	if opts.RetryStrategy == RetryExponentialBackoff {
+		base = opts.RetryInterval * time.Duration(math.Pow(2, float64(attempt)))
+	}
+	if opts.Jitter > 0 {
+		base += time.Duration(mathrand.Int63n(int64(opts.Jitter) + 1))
+	}
+	return base
+}
+
+// LockerOptions configures NewLocker.
+type LockerOptions struct {
+	// Instances, when set, makes NewLocker run the Redlock quorum algorithm
+	// across these independent redis endpoints in addition to the Cache
+	// passed to NewLocker. A lock is only held once a majority of all
+	// instances agree.
+	Instances []Cache
+}
+
+// Locker obtains distributed locks backed by redis.
+type Locker interface {
+	Obtain(ctx context.Context, key string, ttl time.Duration, opts *ObtainOptions) (*Lock, error)
+}
+
+type locker struct {
+	clients []redis.UniversalClient
+	quorum  int
+}
+
+// NewLocker builds a Locker implementing the Redlock algorithm on top of c.
+// Acquisition uses SET key token NX PX ttl; release and refresh run Lua
+// scripts that only act when the caller's token still matches, so one
+// holder can never release or extend another holder's lock.
+func NewLocker(c Cache, opts LockerOptions) Locker {
+	clients := make([]redis.UniversalClient, 0, 1+len(opts.Instances))
+	clients = append(clients, c.GetClient())
+	for _, inst := range opts.Instances {
+		clients = append(clients, inst.GetClient())
+	}
+
+	return &locker{
+		clients: clients,
+		quorum:  len(clients)/2 + 1,
+	}
+}
+
+// Lock is a held distributed lock. Release it as soon as the critical
+// section is done.
+type Lock struct {
+	locker *locker
+	key    string
+	token  string
+	ttl    time.Duration
+}
+
+// Obtain acquires key for ttl, retrying per opts until a quorum of instances
+// agree or the retry budget is exhausted. opts may be nil, which is
+// equivalent to RetryNone.
+func (l *locker) Obtain(ctx context.Context, key string, ttl time.Duration, opts *ObtainOptions) (*Lock, error) {
+	if opts == nil {
+		opts = &ObtainOptions{}
+	}
+	opts.defaults()
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Time{}
+	if opts.MaxWait > 0 {
+		deadline = time.Now().Add(opts.MaxWait)
+	}
+
+	for attempt := 0; ; attempt++ {
+		lock, err := l.acquire(ctx, key, token, ttl)
+		if err == nil {
+			return lock, nil
+		}
+
+		if opts.RetryStrategy == RetryNone || attempt >= opts.MaxRetries {
+			return nil, ErrNotObtained
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, ErrNotObtained
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(opts.delay(attempt)):
+		}
+	}
+}
+
+func (l *locker) acquire(ctx context.Context, key, token string, ttl time.Duration) (*Lock, error) {
+	start := time.Now()
+
+	acquired := 0
+	for _, client := range l.clients {
+		ok, err := client.SetNX(ctx, key, token, ttl).Result()
+		if err == nil && ok {
+			acquired++
+		}
+	}
+
+	drift := time.Duration(float64(ttl)*clockDriftFactor) + 2*time.Millisecond
+	validity := ttl - time.Since(start) - drift
+
+	if acquired >= l.quorum && validity > 0 {
+		return &Lock{locker: l, key: key, token: token, ttl: ttl}, nil
+	}
+
+	l.releaseToken(context.Background(), key, token)
+	return nil, ErrNotObtained
+}
+
+func (l *locker) releaseToken(ctx context.Context, key, token string) int {
+	released := 0
+	for _, client := range l.clients {
+		result, err := releaseScript.Run(ctx, client, []string{key}, token).Int64()
+		if err == nil && result == 1 {
+			released++
+		}
+	}
+	return released
+}
+
+// Refresh extends the lock's TTL, as long as this Lock still holds it on a
+// quorum of instances.
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	extended := 0
+	for _, client := range l.locker.clients {
+		result, err := refreshScript.Run(ctx, client, []string{l.key}, l.token, ttl.Milliseconds()).Int64()
+		if err == nil && result == 1 {
+			extended++
+		}
+	}
+
+	if extended < l.locker.quorum {
+		return ErrNotObtained
+	}
+	l.ttl = ttl
+	return nil
+}
+
+// Release gives up the lock. It returns ErrNotObtained if a quorum of
+// instances could not be confirmed released, e.g. because they are
+// unreachable or the lock's TTL already expired; in that case the key may
+// still be sitting in redis until it expires on its own.
+func (l *Lock) Release(ctx context.Context) error {
+	released := l.locker.releaseToken(ctx, l.key, l.token)
+	if released < l.locker.quorum {
+		return ErrNotObtained
+	}
+	return nil
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 16)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
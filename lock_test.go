@@ -0,0 +1,68 @@
+package rediscache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/betas-in/logger"
+	"github.com/betas-in/utils"
+)
+
+func TestLockerObtainIsExclusiveUntilReleased(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	c, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	locker := NewLocker(c, LockerOptions{})
+	ctx := context.Background()
+	key := "test:lock:exclusive"
+
+	lock, err := locker.Obtain(ctx, key, time.Second, nil)
+	utils.Test().Nil(t, err)
+
+	_, err = locker.Obtain(ctx, key, time.Second, nil)
+	if err != ErrNotObtained {
+		t.Fatalf("expected ErrNotObtained while the lock is still held, got %v", err)
+	}
+
+	err = lock.Release(ctx)
+	utils.Test().Nil(t, err)
+
+	second, err := locker.Obtain(ctx, key, time.Second, nil)
+	utils.Test().Nil(t, err)
+	utils.Test().Nil(t, second.Release(ctx))
+}
+
+func TestLockerRefreshExtendsOnlyWhileHeldByTheSameToken(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	c, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	locker := NewLocker(c, LockerOptions{})
+	ctx := context.Background()
+	key := "test:lock:refresh"
+
+	lock, err := locker.Obtain(ctx, key, 200*time.Millisecond, nil)
+	utils.Test().Nil(t, err)
+	defer lock.Release(ctx)
+
+	utils.Test().Nil(t, lock.Refresh(ctx, time.Second))
+
+	err = lock.Release(ctx)
+	utils.Test().Nil(t, err)
+
+	// Release deleted the key, so the token no longer matches and Refresh on
+	// the already-released lock must not succeed.
+	if err := lock.Refresh(ctx, time.Second); err != ErrNotObtained {
+		t.Fatalf("expected ErrNotObtained refreshing a released lock, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package rediscache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+// localLRU is a small, bounded, TTL-aware in-process cache. It backs
+// LayeredCache so repeated reads of a hot key don't round-trip to redis.
+type localLRU struct {
+	mu       sync.Mutex
+	size     int
+	ttl      time.Duration
+	list     *list.List
+	elements map[string]*list.Element
+}
+
+func newLocalLRU(size int, ttl time.Duration) *localLRU {
+	if size <= 0 {
+		size = 1
+	}
+	return &localLRU{
+		size:     size,
+		ttl:      ttl,
+		list:     list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+func (l *localLRU) get(key string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.elements[key]
+	if !ok {
+		return "", false
+	}
+
+	entry := el.Value.(*lruEntry)
+	if l.ttl > 0 && time.Now().After(entry.expiresAt) {
+		l.list.Remove(el)
+		delete(l.elements, key)
+		return "", false
+	}
+
+	l.list.MoveToFront(el)
+	return entry.value, true
+}
+
+func (l *localLRU) set(key, value string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var expiresAt time.Time
+	if l.ttl > 0 {
+		expiresAt = time.Now().Add(l.ttl)
+	}
+
+	if el, ok := l.elements[key]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		l.list.MoveToFront(el)
+		return
+	}
+
+	el := l.list.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	l.elements[key] = el
+
+	for l.list.Len() > l.size {
+		oldest := l.list.Back()
+		if oldest == nil {
+			break
+		}
+		l.list.Remove(oldest)
+		delete(l.elements, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (l *localLRU) del(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.elements[key]; ok {
+		l.list.Remove(el)
+		delete(l.elements, key)
+	}
+}
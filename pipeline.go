@@ -0,0 +1,89 @@
+package rediscache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Pipeliner queues commands and sends them to redis in a single round-trip
+// on Exec. It mirrors the subset of Cache already exposed by this package;
+// each call returns a *redis.XCmd future whose Result() is only valid after
+// Exec runs.
+type Pipeliner interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	ZAdd(ctx context.Context, key string, members ...*redis.Z) *redis.IntCmd
+	RPush(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
+	Incr(ctx context.Context, key string) *redis.IntCmd
+	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
+	Exec(ctx context.Context) ([]redis.Cmder, error)
+}
+
+// Tx exposes the commands available inside a Watch callback: direct command
+// execution against the watched connection, plus TxPipelined to commit a
+// MULTI/EXEC batch once the CAS check has been made.
+type Tx interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	Incr(ctx context.Context, key string) *redis.IntCmd
+	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
+	TxPipelined(ctx context.Context, fn func(Pipeliner) error) ([]redis.Cmder, error)
+}
+
+type txWrapper struct {
+	tx *redis.Tx
+}
+
+func (t *txWrapper) Get(ctx context.Context, key string) *redis.StringCmd {
+	return t.tx.Get(ctx, key)
+}
+
+func (t *txWrapper) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	return t.tx.Set(ctx, key, value, expiration)
+}
+
+func (t *txWrapper) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	return t.tx.Del(ctx, keys...)
+}
+
+func (t *txWrapper) Incr(ctx context.Context, key string) *redis.IntCmd {
+	return t.tx.Incr(ctx, key)
+}
+
+func (t *txWrapper) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	return t.tx.Expire(ctx, key, expiration)
+}
+
+func (t *txWrapper) TxPipelined(ctx context.Context, fn func(Pipeliner) error) ([]redis.Cmder, error) {
+	return t.tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		return fn(pipe)
+	})
+}
+
+// Pipeline queues commands without blocking them on each other; it is not a
+// transaction, so another client's commands may interleave with the batch.
+func (c *cache) Pipeline(ctx context.Context) Pipeliner {
+	return c.client.Pipeline()
+}
+
+// TxPipeline queues commands inside MULTI/EXEC, so the batch is applied
+// atomically.
+func (c *cache) TxPipeline(ctx context.Context) Pipeliner {
+	return c.client.TxPipeline()
+}
+
+// Watch runs fn against a connection that has WATCHed keys, for optimistic
+// locking CAS flows: read the watched keys inside fn, decide what to write,
+// then commit with fn's Tx.TxPipelined. If a watched key changes before the
+// TxPipelined MULTI/EXEC commits, redis aborts the transaction and
+// TxPipelined returns redis.TxFailedErr; the caller is responsible for
+// retrying Watch.
+func (c *cache) Watch(ctx context.Context, fn func(Tx) error, keys ...string) error {
+	return c.client.Watch(ctx, func(tx *redis.Tx) error {
+		return fn(&txWrapper{tx: tx})
+	}, keys...)
+}
@@ -0,0 +1,84 @@
+package rediscache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/betas-in/logger"
+	"github.com/betas-in/utils"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestPipelineBatchesCommandsInOneRoundTrip(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	c, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	_, err = c.Del(ctx, "test:pipeline:counter")
+	utils.Test().Nil(t, err)
+
+	pipe := c.Pipeline(ctx)
+	set := pipe.Set(ctx, "test:pipeline:key", "value", time.Minute)
+	incr := pipe.Incr(ctx, "test:pipeline:counter")
+
+	_, err = pipe.Exec(ctx)
+	utils.Test().Nil(t, err)
+	utils.Test().Nil(t, set.Err())
+	utils.Test().Nil(t, incr.Err())
+	utils.Test().Equals(t, int64(1), incr.Val())
+
+	value, err := c.Get(ctx, "test:pipeline:key")
+	utils.Test().Nil(t, err)
+	utils.Test().Equals(t, "value", value)
+}
+
+// TestWatchAbortsOnConcurrentModification asserts that when a watched key
+// changes between Watch's read and its TxPipelined commit, redis aborts the
+// transaction with redis.TxFailedErr rather than applying a stale write.
+func TestWatchAbortsOnConcurrentModification(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	c, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	key := "test:watch:key"
+	_, err = c.Set(ctx, key, "0", time.Minute)
+	utils.Test().Nil(t, err)
+
+	err = c.Watch(ctx, func(tx Tx) error {
+		if err := tx.Get(ctx, key).Err(); err != nil {
+			return err
+		}
+
+		// Simulate another client racing in and changing the watched key
+		// after this transaction has read it but before it commits.
+		_, err = c.Set(ctx, key, "raced", time.Minute)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe Pipeliner) error {
+			pipe.Set(ctx, key, "1", time.Minute)
+			return nil
+		})
+		return err
+	}, key)
+
+	if err != redis.TxFailedErr {
+		t.Fatalf("expected redis.TxFailedErr from the aborted transaction, got %v", err)
+	}
+
+	value, err := c.Get(ctx, key)
+	utils.Test().Nil(t, err)
+	utils.Test().Equals(t, "raced", value)
+}
@@ -0,0 +1,137 @@
+package rediscache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Message is a pub/sub message delivered to a Subscription.
+type Message struct {
+	Channel string
+	Pattern string
+	Payload string
+}
+
+// Subscription delivers messages from one or more subscribed
+// channels/patterns until Close is called or its context is canceled.
+type Subscription interface {
+	Channel() <-chan *Message
+	Close() error
+}
+
+type subscription struct {
+	sub    *redis.PubSub
+	cancel context.CancelFunc
+	out    chan *Message
+}
+
+// newSubscription re-reads sub in the background, retrying with backoff on
+// errors so a long-lived subscriber survives reconnects, and forwards
+// delivered messages to out.
+func newSubscription(ctx context.Context, sub *redis.PubSub) Subscription {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &subscription{sub: sub, cancel: cancel, out: make(chan *Message)}
+	go s.run(ctx)
+	return s
+}
+
+func (s *subscription) run(ctx context.Context) {
+	defer close(s.out)
+
+	const (
+		minBackoff = 100 * time.Millisecond
+		maxBackoff = 5 * time.Second
+	)
+	backoff := minBackoff
+
+	for {
+		msg, err := s.sub.ReceiveMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+		backoff = minBackoff
+
+		select {
+		case s.out <- &Message{Channel: msg.Channel, Pattern: msg.Pattern, Payload: msg.Payload}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *subscription) Channel() <-chan *Message {
+	return s.out
+}
+
+func (s *subscription) Close() error {
+	s.cancel()
+	return s.sub.Close()
+}
+
+// Publish a message on channel.
+func (c *cache) Publish(ctx context.Context, channel string, message interface{}) (int64, error) {
+	return c.client.Publish(ctx, channel, message).Result()
+}
+
+// Subscribe to one or more channels.
+func (c *cache) Subscribe(ctx context.Context, channels ...string) Subscription {
+	return newSubscription(ctx, c.client.Subscribe(ctx, channels...))
+}
+
+// PSubscribe to one or more glob patterns.
+func (c *cache) PSubscribe(ctx context.Context, patterns ...string) Subscription {
+	return newSubscription(ctx, c.client.PSubscribe(ctx, patterns...))
+}
+
+// KeyEvent is a single keyspace-notification event, e.g. Key "foo", Event
+// "expired".
+type KeyEvent struct {
+	Key   string
+	Event string
+}
+
+// WatchKeyspace subscribes to keyspace notifications for keys matching
+// pattern (a key glob, as used by KEYS/PSUBSCRIBE) and decodes them into
+// KeyEvents. The server must have notify-keyspace-events configured (e.g.
+// "Kg$xeg") for anything to arrive; WatchKeyspace does not set it, since
+// that is a server-wide setting with its own tradeoffs.
+func (c *cache) WatchKeyspace(ctx context.Context, pattern string) (<-chan KeyEvent, error) {
+	sub := c.PSubscribe(ctx, "__keyspace@*__:"+pattern)
+	out := make(chan KeyEvent)
+
+	go func() {
+		<-ctx.Done()
+		sub.Close()
+	}()
+
+	go func() {
+		defer close(out)
+		for msg := range sub.Channel() {
+			// Keyspace channels are "__keyspace@<db>__:<key>" and carry the
+			// event name as the payload.
+			key := msg.Channel[strings.Index(msg.Channel, "__:")+3:]
+			select {
+			case out <- KeyEvent{Key: key, Event: msg.Payload}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package rediscache
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/betas-in/logger"
+	"github.com/betas-in/utils"
+)
+
+func TestPublishSubscribeRoundTrip(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	c, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	sub := c.Subscribe(ctx, "test:pubsub:channel")
+	defer sub.Close()
+	// Give the subscription time to register with redis before publishing.
+	time.Sleep(50 * time.Millisecond)
+
+	_, err = c.Publish(ctx, "test:pubsub:channel", "hello")
+	utils.Test().Nil(t, err)
+
+	select {
+	case msg := <-sub.Channel():
+		utils.Test().Equals(t, "hello", msg.Payload)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the published message")
+	}
+}
+
+// TestWatchKeyspaceClosesSubscriptionOnCancel is a regression test for a
+// leak where canceling WatchKeyspace's ctx did not unblock the underlying
+// PSubscribe's ReceiveMessage, leaking a goroutine and a redis connection
+// per call forever. It asserts goroutine count returns to baseline shortly
+// after cancellation, the same way the leak was originally diagnosed.
+func TestWatchKeyspaceClosesSubscriptionOnCancel(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	c, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	runtime.GC()
+	baseline := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = c.WatchKeyspace(ctx, "*")
+	utils.Test().Nil(t, err)
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		runtime.GC()
+		if runtime.NumGoroutine() <= baseline {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatalf("goroutine count did not return to baseline (%d) after canceling ctx, got %d", baseline, runtime.NumGoroutine())
+}
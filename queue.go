@@ -0,0 +1,95 @@
+package rediscache
+
+import (
+	"context"
+	"time"
+)
+
+// Backend selects the storage primitive a Queue is built on.
+type Backend string
+
+const (
+	// BackendList stores jobs in a ZSET (for delayed jobs) and Lists (for
+	// ready/processing/dead jobs). This is the default.
+	BackendList Backend = "list"
+	// BackendStream stores jobs in a redis Stream and uses a consumer group
+	// for delivery and acknowledgement.
+	BackendStream Backend = "stream"
+)
+
+// Job is a single unit of work delivered to a Consume handler.
+type Job struct {
+	ID      string
+	Payload []byte
+	// Retries is how many times this job has already been redelivered after
+	// a handler error. A redelivery after a crashed consumer is not counted.
+	Retries int
+}
+
+// QueueOptions configures NewQueue.
+type QueueOptions struct {
+	// Backend selects BackendList (default) or BackendStream.
+	Backend Backend
+	// ConsumerName identifies this process among the queue's consumers. It
+	// must be unique per running consumer. Defaults to a random value.
+	ConsumerName string
+	// GroupName is the consumer group name, used only for BackendStream.
+	// Defaults to "<queue-name>-group".
+	GroupName string
+	// VisibilityTimeout is how long a job may stay claimed by a consumer
+	// before it is assumed crashed and the job is returned to the queue.
+	// Defaults to 30s.
+	VisibilityTimeout time.Duration
+	// MaxRetries bounds how many times a job is redelivered after a handler
+	// error before it is moved to the dead-letter queue; redeliveries after a
+	// crashed consumer do not count against it. Defaults to 5.
+	MaxRetries int
+	// PollInterval is how often Consume checks for due delayed jobs and
+	// reaps stuck consumers. Defaults to 1s.
+	PollInterval time.Duration
+}
+
+func (opts *QueueOptions) defaults() {
+	if opts.Backend == "" {
+		opts.Backend = BackendList
+	}
+	if opts.ConsumerName == "" {
+		token, err := randomToken()
+		if err == nil {
+			opts.ConsumerName = token
+		}
+	}
+	if opts.VisibilityTimeout == 0 {
+		opts.VisibilityTimeout = 30 * time.Second
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = 5
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = time.Second
+	}
+}
+
+// Queue is a reliable, optionally-delayed job queue built on top of Cache.
+type Queue interface {
+	// Enqueue makes payload available for delivery after delay (zero for
+	// immediate delivery) and returns the job ID.
+	Enqueue(ctx context.Context, payload []byte, delay time.Duration) (string, error)
+	// Consume blocks, delivering jobs to handler one at a time until ctx is
+	// canceled. A handler error redelivers the job, up to MaxRetries, after
+	// which it is moved to the dead-letter queue.
+	Consume(ctx context.Context, handler func(Job) error) error
+	Close() error
+}
+
+// NewQueue builds a Queue named name on top of c, backed by opts.Backend.
+func NewQueue(c Cache, name string, opts QueueOptions) Queue {
+	opts.defaults()
+
+	switch opts.Backend {
+	case BackendStream:
+		return newStreamQueue(c, name, opts)
+	default:
+		return newListQueue(c, name, opts)
+	}
+}
@@ -0,0 +1,168 @@
+package rediscache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// promoteScript atomically moves delayed jobs whose score (execute-at unix
+// time) has passed from the delayed ZSET into the ready List.
+var promoteScript = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, item in ipairs(items) do
+	redis.call('ZREM', KEYS[1], item)
+	redis.call('RPUSH', KEYS[2], item)
+end
+return #items
+`)
+
+type jobEnvelope struct {
+	ID      string `json:"id"`
+	Payload []byte `json:"payload"`
+	Retries int    `json:"retries"`
+}
+
+type listQueue struct {
+	client redis.UniversalClient
+	name   string
+	opts   QueueOptions
+}
+
+func newListQueue(c Cache, name string, opts QueueOptions) Queue {
+	return &listQueue{client: c.GetClient(), name: name, opts: opts}
+}
+
+func (q *listQueue) delayedKey() string   { return q.name + ":delayed" }
+func (q *listQueue) readyKey() string     { return q.name + ":ready" }
+func (q *listQueue) deadKey() string      { return q.name + ":dead" }
+func (q *listQueue) consumersKey() string { return q.name + ":consumers" }
+func (q *listQueue) processingKey(consumer string) string {
+	return q.name + ":processing:" + consumer
+}
+
+func (q *listQueue) Enqueue(ctx context.Context, payload []byte, delay time.Duration) (string, error) {
+	id, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := json.Marshal(jobEnvelope{ID: id, Payload: payload})
+	if err != nil {
+		return "", err
+	}
+
+	if delay <= 0 {
+		_, err = q.client.RPush(ctx, q.readyKey(), raw).Result()
+		return id, err
+	}
+
+	executeAt := time.Now().Add(delay)
+	_, err = q.client.ZAdd(ctx, q.delayedKey(), &redis.Z{Score: float64(executeAt.Unix()), Member: raw}).Result()
+	return id, err
+}
+
+func (q *listQueue) promoteDelayed(ctx context.Context) {
+	promoteScript.Run(ctx, q.client, []string{q.delayedKey(), q.readyKey()}, time.Now().Unix(), 100)
+}
+
+// reapStuckConsumers returns jobs claimed by consumers whose visibility
+// deadline has passed (crashed mid-processing) back to ready.
+func (q *listQueue) reapStuckConsumers(ctx context.Context) {
+	stuck, err := q.client.ZRangeByScore(ctx, q.consumersKey(), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(time.Now().Unix(), 10),
+	}).Result()
+	if err != nil {
+		return
+	}
+
+	for _, consumer := range stuck {
+		processing := q.processingKey(consumer)
+		for {
+			item, err := q.client.LPop(ctx, processing).Result()
+			if err != nil {
+				break
+			}
+			q.client.RPush(ctx, q.readyKey(), item)
+		}
+		q.client.ZRem(ctx, q.consumersKey(), consumer)
+	}
+}
+
+func (q *listQueue) heartbeat(ctx context.Context) {
+	deadline := time.Now().Add(q.opts.VisibilityTimeout)
+	q.client.ZAdd(ctx, q.consumersKey(), &redis.Z{Score: float64(deadline.Unix()), Member: q.opts.ConsumerName})
+}
+
+func (q *listQueue) Consume(ctx context.Context, handler func(Job) error) error {
+	processing := q.processingKey(q.opts.ConsumerName)
+	defer q.client.ZRem(context.Background(), q.consumersKey(), q.opts.ConsumerName)
+
+	ticker := time.NewTicker(q.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			q.promoteDelayed(ctx)
+			q.reapStuckConsumers(ctx)
+		default:
+		}
+
+		q.heartbeat(ctx)
+
+		// LMove (not BLMove) because go-redis floors a blocking command's
+		// timeout to 1s, which would silently override a sub-second
+		// PollInterval. Polling with a plain sleep keeps PollInterval
+		// accurate down to millisecond precision, like the stream backend.
+		raw, err := q.client.LMove(ctx, q.readyKey(), processing, "LEFT", "RIGHT").Result()
+		if err == redis.Nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(q.opts.PollInterval):
+			}
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		var job jobEnvelope
+		if err := json.Unmarshal([]byte(raw), &job); err != nil {
+			q.client.LRem(ctx, processing, 1, raw)
+			continue
+		}
+
+		handlerErr := handler(Job{ID: job.ID, Payload: job.Payload, Retries: job.Retries})
+		q.client.LRem(ctx, processing, 1, raw)
+
+		if handlerErr == nil {
+			continue
+		}
+
+		job.Retries++
+		retried, err := json.Marshal(job)
+		if err != nil {
+			continue
+		}
+		if job.Retries > q.opts.MaxRetries {
+			q.client.RPush(ctx, q.deadKey(), retried)
+			continue
+		}
+		q.client.RPush(ctx, q.readyKey(), retried)
+	}
+}
+
+func (q *listQueue) Close() error {
+	return nil
+}
@@ -0,0 +1,188 @@
+package rediscache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// streamPromoteScript atomically moves delayed jobs whose score (execute-at
+// unix time) has passed from the delayed ZSET into the stream, the same way
+// the list backend's promoteScript does for its ready List. Running the
+// range, the XADD and the ZREM in one script is what makes this safe when
+// several consumers of the same group poll concurrently: without it, two
+// consumers can both read the same due member before either removes it and
+// both re-add it to the stream, delivering the job twice.
+var streamPromoteScript = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, item in ipairs(items) do
+	redis.call('ZREM', KEYS[1], item)
+	local sep = string.find(item, ':')
+	local id = string.sub(item, 1, sep - 1)
+	local payload = string.sub(item, sep + 1)
+	redis.call('XADD', KEYS[2], '*', 'id', id, 'payload', payload, 'retries', '0')
+end
+return #items
+`)
+
+type streamQueue struct {
+	client redis.UniversalClient
+	name   string
+	group  string
+	opts   QueueOptions
+}
+
+func newStreamQueue(c Cache, name string, opts QueueOptions) Queue {
+	group := opts.GroupName
+	if group == "" {
+		group = name + "-group"
+	}
+	return &streamQueue{client: c.GetClient(), name: name, group: group, opts: opts}
+}
+
+func (q *streamQueue) delayedKey() string { return q.name + ":delayed" }
+func (q *streamQueue) deadKey() string    { return q.name + ":dead" }
+
+func (q *streamQueue) ensureGroup(ctx context.Context) {
+	q.client.XGroupCreateMkStream(ctx, q.name, q.group, "0")
+}
+
+func (q *streamQueue) Enqueue(ctx context.Context, payload []byte, delay time.Duration) (string, error) {
+	if delay <= 0 {
+		return q.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: q.name,
+			Values: map[string]interface{}{"payload": payload, "retries": 0},
+		}).Result()
+	}
+
+	id, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	executeAt := time.Now().Add(delay)
+	_, err = q.client.ZAdd(ctx, q.delayedKey(), &redis.Z{
+		Score:  float64(executeAt.Unix()),
+		Member: id + ":" + string(payload),
+	}).Result()
+	return id, err
+}
+
+// promoteDelayed moves due delayed jobs into the stream. Delayed payloads
+// are stored as "<id>:<payload>" since a ZSET member has no side fields; the
+// id is carried into the promoted entry's "id" field so Enqueue's returned
+// ID for a delayed job still identifies it after promotion, the way the list
+// backend's envelope ID survives its own promotion.
+func (q *streamQueue) promoteDelayed(ctx context.Context) {
+	streamPromoteScript.Run(ctx, q.client, []string{q.delayedKey(), q.name}, time.Now().Unix(), 100)
+}
+
+// reclaimStuck hands pending messages that no consumer has acked within
+// VisibilityTimeout to this consumer, so a crashed consumer's jobs still get
+// retried.
+func (q *streamQueue) reclaimStuck(ctx context.Context) []redis.XMessage {
+	messages, _, err := q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   q.name,
+		Group:    q.group,
+		MinIdle:  q.opts.VisibilityTimeout,
+		Start:    "0",
+		Count:    100,
+		Consumer: q.opts.ConsumerName,
+	}).Result()
+	if err != nil {
+		return nil
+	}
+	return messages
+}
+
+// messageJob decodes msg into a Job. A promoted delayed job (and any job
+// that has already been through handle's retry path) carries its stable ID
+// in the "id" field; a job delivered straight from Enqueue has none, so its
+// Job.ID falls back to the stream entry ID Enqueue itself returned.
+func messageJob(msg redis.XMessage) Job {
+	job := Job{ID: msg.ID}
+	if id, ok := msg.Values["id"].(string); ok && id != "" {
+		job.ID = id
+	}
+	if payload, ok := msg.Values["payload"].(string); ok {
+		job.Payload = []byte(payload)
+	}
+	if retries, ok := msg.Values["retries"].(string); ok {
+		n, err := strconv.Atoi(retries)
+		if err == nil {
+			job.Retries = n
+		}
+	}
+	return job
+}
+
+func (q *streamQueue) handle(ctx context.Context, msg redis.XMessage, handler func(Job) error) {
+	job := messageJob(msg)
+
+	err := handler(job)
+	q.client.XAck(ctx, q.name, q.group, msg.ID)
+	if err == nil {
+		return
+	}
+
+	job.Retries++
+	if job.Retries > q.opts.MaxRetries {
+		q.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: q.deadKey(),
+			Values: map[string]interface{}{"id": job.ID, "payload": job.Payload, "retries": job.Retries},
+		})
+		return
+	}
+	q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.name,
+		Values: map[string]interface{}{"id": job.ID, "payload": job.Payload, "retries": job.Retries},
+	})
+}
+
+func (q *streamQueue) Consume(ctx context.Context, handler func(Job) error) error {
+	q.ensureGroup(ctx)
+
+	ticker := time.NewTicker(q.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			q.promoteDelayed(ctx)
+			for _, msg := range q.reclaimStuck(ctx) {
+				q.handle(ctx, msg, handler)
+			}
+		default:
+		}
+
+		streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    q.group,
+			Consumer: q.opts.ConsumerName,
+			Streams:  []string{q.name, ">"},
+			Count:    10,
+			Block:    q.opts.PollInterval,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				q.handle(ctx, msg, handler)
+			}
+		}
+	}
+}
+
+func (q *streamQueue) Close() error {
+	return nil
+}
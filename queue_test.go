@@ -0,0 +1,83 @@
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/betas-in/logger"
+	"github.com/betas-in/utils"
+)
+
+func TestQueueListEnqueueConsumeRoundTrip(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	c, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	q := NewQueue(c, "test:queue:roundtrip", QueueOptions{PollInterval: 10 * time.Millisecond})
+
+	_, err = q.Enqueue(context.Background(), []byte("hello"), 0)
+	utils.Test().Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	received := make(chan Job, 1)
+	go q.Consume(ctx, func(job Job) error {
+		received <- job
+		cancel()
+		return nil
+	})
+
+	select {
+	case job := <-received:
+		utils.Test().Equals(t, "hello", string(job.Payload))
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the job to be delivered")
+	}
+}
+
+// TestQueueListDeadLettersAfterMaxRetries asserts a handler that always fails
+// eventually moves the job to the dead-letter list instead of retrying it
+// forever.
+func TestQueueListDeadLettersAfterMaxRetries(t *testing.T) {
+	log := logger.NewLogger(0, true)
+	c, err := NewCache(benchConfig(), log)
+	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	name := "test:queue:deadletter"
+	q := NewQueue(c, name, QueueOptions{PollInterval: 10 * time.Millisecond, MaxRetries: 1})
+
+	_, err = q.Enqueue(context.Background(), []byte("fails"), 0)
+	utils.Test().Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	go q.Consume(ctx, func(job Job) error {
+		return errors.New("handler always fails")
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	var dead int64
+	for time.Now().Before(deadline) {
+		dead, err = c.LLen(context.Background(), name+":dead")
+		utils.Test().Nil(t, err)
+		if dead >= 1 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if dead < 1 {
+		t.Fatalf("expected the job to land in the dead-letter list after exceeding MaxRetries, got length %d", dead)
+	}
+}
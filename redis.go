@@ -2,16 +2,14 @@ package rediscache
 
 import (
 	"context"
-	"fmt"
 	"time"
 
-	"github.com/betas-in/logger"
 	"github.com/go-redis/redis/v8"
 )
 
 // Cache ...
 type Cache interface {
-	GetClient() *redis.Client
+	GetClient() redis.UniversalClient
 	Ping(ctx context.Context) (string, error)
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) (string, error)
@@ -35,82 +33,16 @@ type Cache interface {
 	SAdd(ctx context.Context, key string, members ...interface{}) (int64, error)
 	Incr(ctx context.Context, key string) (int64, error)
 	Expire(ctx context.Context, key string, expiration time.Duration) (bool, error)
+	Pipeline(ctx context.Context) Pipeliner
+	TxPipeline(ctx context.Context) Pipeliner
+	Watch(ctx context.Context, fn func(Tx) error, keys ...string) error
+	Publish(ctx context.Context, channel string, message interface{}) (int64, error)
+	Subscribe(ctx context.Context, channels ...string) Subscription
+	PSubscribe(ctx context.Context, patterns ...string) Subscription
+	WatchKeyspace(ctx context.Context, pattern string) (<-chan KeyEvent, error)
 	Close() error
 }
 
-type cache struct {
-	client *redis.Client
-	log    *logger.Logger
-	config *Config
-}
-
-type Config struct {
-	Host       string
-	Port       int
-	Password   string
-	DB         int
-	MaxRetries int
-	PoolSize   int
-}
-
-// NewCache ...
-func NewCache(conf *Config, log *logger.Logger) (Cache, error) {
-	c := cache{
-		log:    log,
-		config: conf,
-	}
-	c.defaults()
-
-	client := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", c.config.Host, c.config.Port),
-		Password: c.config.Password,
-		DB:       c.config.DB,
-		OnConnect: func(ctx context.Context, conn *redis.Conn) error {
-			log.Info("redis").Msgf("connected to %s:%d", c.config.Host, c.config.Port)
-			return nil
-		},
-		MaxRetries: c.config.MaxRetries,
-		PoolSize:   c.config.PoolSize,
-	})
-
-	ctx := context.Background()
-	_, err := client.Ping(ctx).Result()
-	if err != nil {
-		log.Fatal("redis").Msgf("Could not ping redis %+v", err)
-	}
-	c.client = client
-
-	return &c, nil
-}
-
-func (c *cache) defaults() {
-	if c.config.Host == "" {
-		c.config.Host = "127.0.0.1"
-	}
-	if c.config.Port == 0 {
-		c.config.Port = 6379
-	}
-	if c.config.DB == 0 {
-		c.config.DB = 0
-	}
-	if c.config.MaxRetries == 0 {
-		c.config.MaxRetries = 3
-	}
-	if c.config.PoolSize == 0 {
-		c.config.PoolSize = 10
-	}
-}
-
-// GetClient the redis object
-func (c *cache) GetClient() *redis.Client {
-	return c.client
-}
-
-// Close the redis object
-func (c *cache) Close() error {
-	return c.client.Close()
-}
-
 // Get from redis
 func (c *cache) Get(ctx context.Context, key string) (string, error) {
 	response, err := c.client.Get(ctx, key).Result()
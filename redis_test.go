@@ -19,6 +19,9 @@ func TestRedis(t *testing.T) {
 
 	c, err := NewCache(&conf, log)
 	utils.Test().Nil(t, err)
+	if err != nil {
+		return
+	}
 
 	_ = c.GetClient()
 	err = c.Close()
@@ -0,0 +1,95 @@
+package rediscache
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// parseURI populates c from a connection URI, overriding Mode, Host/Port,
+// SentinelAddrs/MasterName, Password and DB as appropriate for the scheme.
+//
+// Supported forms:
+//
+//	redis://[:password@]host:port[/db]
+//	rediss://[:password@]host:port[/db]            (TLS)
+//	redis+sentinel://[:password@]host1:port1,host2:port2/masterName[/db]
+func (c *Config) parseURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("rediscache: invalid uri: %w", err)
+	}
+
+	password := ""
+	if u.User != nil {
+		password, _ = u.User.Password()
+	}
+
+	switch u.Scheme {
+	case "redis", "rediss":
+		c.Mode = ModeStandalone
+		c.Password = password
+		c.TLSEnabled = u.Scheme == "rediss"
+
+		host, port, err := splitHostPort(u.Host, 6379)
+		if err != nil {
+			return err
+		}
+		c.Host = host
+		c.Port = port
+
+		if db, ok, err := pathDB(u.Path, 0); err != nil {
+			return err
+		} else if ok {
+			c.DB = db
+		}
+	case "redis+sentinel":
+		c.Mode = ModeSentinel
+		c.SentinelPassword = password
+		c.SentinelAddrs = strings.Split(u.Host, ",")
+
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) == 0 || parts[0] == "" {
+			return fmt.Errorf("rediscache: redis+sentinel uri is missing the master name")
+		}
+		c.MasterName = parts[0]
+
+		if len(parts) > 1 {
+			db, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return fmt.Errorf("rediscache: invalid db in uri: %w", err)
+			}
+			c.DB = db
+		}
+	default:
+		return fmt.Errorf("rediscache: unsupported uri scheme %q", u.Scheme)
+	}
+
+	return nil
+}
+
+func splitHostPort(hostport string, defaultPort int) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return hostport, defaultPort, nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("rediscache: invalid port in uri: %w", err)
+	}
+	return host, port, nil
+}
+
+func pathDB(path string, def int) (int, bool, error) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return def, false, nil
+	}
+	db, err := strconv.Atoi(path)
+	if err != nil {
+		return 0, false, fmt.Errorf("rediscache: invalid db in uri: %w", err)
+	}
+	return db, true, nil
+}